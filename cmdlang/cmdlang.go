@@ -0,0 +1,497 @@
+// Package cmdlang implements the robot command language: a
+// text/scanner-based lexer and parser that turns a program into an AST,
+// plus an evaluator that walks that AST against a robot.World.
+package cmdlang
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/joshjon/verve-example/grid"
+	"github.com/joshjon/verve-example/robot"
+)
+
+// Severity classifies a Diagnostic.
+type Severity int
+
+const (
+	// Error marks a problem that prevented a statement from running.
+	Error Severity = iota
+	// Warning marks a problem that was tolerated.
+	Warning
+)
+
+// String returns the lowercase name of the severity, as printed in a Diagnostic.
+func (s Severity) String() string {
+	if s == Warning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnostic reports a problem found while parsing or running a
+// program, with the source position it occurred at.
+type Diagnostic struct {
+	Line     int
+	Col      int
+	Severity Severity
+	Msg      string
+}
+
+// String formats the diagnostic the way it is written to stderr.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Col, d.Severity, d.Msg)
+}
+
+// Node is a single statement in a program.
+type Node interface{}
+
+// Pos is the 1-based source position of a parsed command, as reported by
+// text/scanner.Position. Consumers that only run a program don't need it,
+// but it lets tools like lsp map a statement back to its source range.
+type Pos struct {
+	Line int
+	Col  int
+}
+
+// PlaceCmd places robot RobotID at (X, Y) facing F. RobotID is "" when
+// the command names no robot, meaning the single default robot.
+type PlaceCmd struct {
+	Pos     Pos
+	RobotID string
+	X, Y    int
+	F       robot.Direction
+}
+
+// PlaceObstacleCmd marks (X, Y) on the grid as impassable.
+type PlaceObstacleCmd struct {
+	Pos  Pos
+	X, Y int
+}
+
+// PlaceHazardCmd marks (X, Y) on the grid as a hazard.
+type PlaceHazardCmd struct {
+	Pos  Pos
+	X, Y int
+}
+
+// MoveCmd advances robot RobotID one unit in its facing direction.
+type MoveCmd struct {
+	Pos     Pos
+	RobotID string
+}
+
+// TurnDirection is the direction of a TurnCmd.
+type TurnDirection int
+
+const (
+	TurnLeft TurnDirection = iota
+	TurnRight
+)
+
+// TurnCmd rotates robot RobotID 90 degrees left or right.
+type TurnCmd struct {
+	Pos     Pos
+	RobotID string
+	Dir     TurnDirection
+}
+
+// ReportCmd prints robot RobotID's position and facing. A bare REPORT
+// (RobotID == "") instead dumps every placed robot, sorted by id.
+type ReportCmd struct {
+	Pos     Pos
+	RobotID string
+}
+
+// MapCmd prints an ASCII rendering of the grid.
+type MapCmd struct{ Pos Pos }
+
+// TickCmd advances every robot in the world one step simultaneously.
+type TickCmd struct{ Pos Pos }
+
+// LabelCmd marks a jump target for GotoCmd.
+type LabelCmd struct {
+	Pos  Pos
+	Name string
+}
+
+// GotoCmd jumps execution to the statement following the named label.
+type GotoCmd struct {
+	Pos   Pos
+	Label string
+}
+
+// RepeatCmd runs Body N times.
+type RepeatCmd struct {
+	Pos  Pos
+	N    int
+	Body []Node
+}
+
+// Program is a parsed, runnable sequence of statements.
+type Program struct {
+	stmts []Node
+}
+
+// Statements returns the program's top-level statements, in source order.
+// A RepeatCmd's Body holds its own nested statements.
+func (p Program) Statements() []Node {
+	return p.stmts
+}
+
+// Parse tokenises r with text/scanner.Scanner and builds a Program,
+// tolerating blank lines and "#" line comments. Malformed statements are
+// skipped and reported as Diagnostics rather than silently dropped.
+func Parse(r io.Reader) (Program, []Diagnostic) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Program{}, []Diagnostic{{Severity: Error, Msg: fmt.Sprintf("reading input: %v", err)}}
+	}
+
+	var s scanner.Scanner
+	s.Init(strings.NewReader(stripComments(string(data))))
+	s.Mode = scanner.ScanIdents | scanner.ScanInts
+	s.Filename = ""
+
+	p := &parser{s: &s}
+	p.next()
+	stmts := p.parseStmts(false)
+	return Program{stmts: stmts}, p.diags
+}
+
+// Run executes the program against w. Runtime diagnostics, such as a
+// GOTO to an undefined label, are written to stderr as they occur.
+func (p Program) Run(w *robot.World) {
+	runProgram(p.stmts, w, os.Stderr)
+}
+
+// stripComments blanks out everything from a '#' to the end of its line
+// so the scanner never sees it, while leaving line numbers unchanged.
+func stripComments(src string) string {
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		if idx := strings.IndexByte(line, '#'); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+type parser struct {
+	s     *scanner.Scanner
+	tok   rune
+	diags []Diagnostic
+}
+
+func (p *parser) next() {
+	p.tok = p.s.Scan()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	pos := p.s.Position
+	p.errorfAt(Pos{Line: pos.Line, Col: pos.Column}, format, args...)
+}
+
+// errorfAt records a diagnostic at an explicit position, for callers that
+// have already advanced the scanner past the token the error concerns.
+func (p *parser) errorfAt(pos Pos, format string, args ...interface{}) {
+	p.diags = append(p.diags, Diagnostic{
+		Line:     pos.Line,
+		Col:      pos.Col,
+		Severity: Error,
+		Msg:      fmt.Sprintf(format, args...),
+	})
+}
+
+// recover skips tokens until the next line, a block's closing '}', or
+// EOF, so one malformed statement doesn't take the rest of the program
+// down with it.
+func (p *parser) recover() {
+	startLine := p.s.Position.Line
+	for p.tok != scanner.EOF && p.tok != '}' {
+		p.next()
+		if p.s.Position.Line > startLine {
+			return
+		}
+	}
+}
+
+func (p *parser) expect(tok rune) bool {
+	if p.tok != tok {
+		p.errorf("expected %q, got %q", string(tok), p.s.TokenText())
+		return false
+	}
+	p.next()
+	return true
+}
+
+func (p *parser) expectInt() (int, bool) {
+	if p.tok != scanner.Int {
+		p.errorf("expected a number, got %q", p.s.TokenText())
+		return 0, false
+	}
+	n, err := strconv.Atoi(p.s.TokenText())
+	if err != nil {
+		p.errorf("invalid number %q", p.s.TokenText())
+		return 0, false
+	}
+	p.next()
+	return n, true
+}
+
+func (p *parser) parseStmts(inBlock bool) []Node {
+	var stmts []Node
+	for p.tok != scanner.EOF {
+		if inBlock && p.tok == '}' {
+			break
+		}
+		if n, ok := p.parseStmt(); ok {
+			stmts = append(stmts, n)
+		}
+	}
+	return stmts
+}
+
+func (p *parser) parseStmt() (Node, bool) {
+	if p.tok == '}' {
+		// Reached only at top level (parseStmts stops on '}' inside a
+		// block before ever calling parseStmt), so there's no enclosing
+		// REPEAT for this brace to close. Consume it directly instead of
+		// calling recover, which stops without advancing when it's
+		// already sitting on '}' and would otherwise spin forever.
+		p.errorf("unexpected %q with no enclosing block", "}")
+		p.next()
+		return nil, false
+	}
+	if p.tok != scanner.Ident {
+		p.errorf("unexpected token %q", p.s.TokenText())
+		p.recover()
+		return nil, false
+	}
+
+	pos := p.pos()
+	word := strings.ToUpper(p.s.TokenText())
+	switch word {
+	case "PLACE":
+		return p.parsePlace(pos)
+	case "PLACE_OBSTACLE":
+		return p.parseCoords(func(x, y int) Node { return PlaceObstacleCmd{Pos: pos, X: x, Y: y} })
+	case "PLACE_HAZARD":
+		return p.parseCoords(func(x, y int) Node { return PlaceHazardCmd{Pos: pos, X: x, Y: y} })
+	case "MOVE":
+		line := p.s.Position.Line
+		p.next()
+		return MoveCmd{Pos: pos, RobotID: p.maybeRobotID(line)}, true
+	case "LEFT":
+		line := p.s.Position.Line
+		p.next()
+		return TurnCmd{Pos: pos, RobotID: p.maybeRobotID(line), Dir: TurnLeft}, true
+	case "RIGHT":
+		line := p.s.Position.Line
+		p.next()
+		return TurnCmd{Pos: pos, RobotID: p.maybeRobotID(line), Dir: TurnRight}, true
+	case "REPORT":
+		line := p.s.Position.Line
+		p.next()
+		return ReportCmd{Pos: pos, RobotID: p.maybeRobotID(line)}, true
+	case "MAP":
+		p.next()
+		return MapCmd{Pos: pos}, true
+	case "TICK":
+		p.next()
+		return TickCmd{Pos: pos}, true
+	case "GOTO":
+		return p.parseGoto(pos)
+	case "REPEAT":
+		return p.parseRepeat(pos)
+	default:
+		return p.parseLabel(pos)
+	}
+}
+
+// pos returns the current scanner position as a Pos.
+func (p *parser) pos() Pos {
+	return Pos{Line: p.s.Position.Line, Col: p.s.Position.Column}
+}
+
+// maybeRobotID consumes and returns a robot id if the current token is
+// one on the same source line as the command keyword that preceded it
+// (e.g. the "R1" in "MOVE R1"); a token on a later line belongs to the
+// next statement, so it returns "" for the default robot without
+// consuming anything.
+func (p *parser) maybeRobotID(keywordLine int) string {
+	if p.tok != scanner.Ident || p.s.Position.Line != keywordLine {
+		return ""
+	}
+	id := p.s.TokenText()
+	p.next()
+	return id
+}
+
+func (p *parser) parsePlace(pos Pos) (Node, bool) {
+	line := p.s.Position.Line
+	p.next() // consume PLACE
+	id := p.maybeRobotID(line)
+	x, ok := p.expectInt()
+	if !ok {
+		p.recover()
+		return nil, false
+	}
+	if !p.expect(',') {
+		p.recover()
+		return nil, false
+	}
+	y, ok := p.expectInt()
+	if !ok {
+		p.recover()
+		return nil, false
+	}
+	if !p.expect(',') {
+		p.recover()
+		return nil, false
+	}
+	if p.tok != scanner.Ident {
+		p.errorf("expected a direction, got %q", p.s.TokenText())
+		p.recover()
+		return nil, false
+	}
+	f, err := robot.ParseDirection(p.s.TokenText())
+	if err != nil {
+		p.errorf("%v", err)
+		p.recover()
+		return nil, false
+	}
+	p.next()
+	return PlaceCmd{Pos: pos, RobotID: id, X: x, Y: y, F: f}, true
+}
+
+func (p *parser) parseCoords(build func(x, y int) Node) (Node, bool) {
+	p.next() // consume PLACE_OBSTACLE / PLACE_HAZARD
+	x, ok := p.expectInt()
+	if !ok {
+		p.recover()
+		return nil, false
+	}
+	if !p.expect(',') {
+		p.recover()
+		return nil, false
+	}
+	y, ok := p.expectInt()
+	if !ok {
+		p.recover()
+		return nil, false
+	}
+	return build(x, y), true
+}
+
+func (p *parser) parseGoto(pos Pos) (Node, bool) {
+	p.next() // consume GOTO
+	if p.tok != scanner.Ident {
+		p.errorf("expected a label after GOTO, got %q", p.s.TokenText())
+		p.recover()
+		return nil, false
+	}
+	label := p.s.TokenText()
+	p.next()
+	return GotoCmd{Pos: pos, Label: label}, true
+}
+
+func (p *parser) parseRepeat(pos Pos) (Node, bool) {
+	p.next() // consume REPEAT
+	n, ok := p.expectInt()
+	if !ok {
+		p.recover()
+		return nil, false
+	}
+	if !p.expect('{') {
+		p.recover()
+		return nil, false
+	}
+	body := p.parseStmts(true)
+	if !p.expect('}') {
+		p.recover()
+		return nil, false
+	}
+	return RepeatCmd{Pos: pos, N: n, Body: body}, true
+}
+
+func (p *parser) parseLabel(pos Pos) (Node, bool) {
+	name := p.s.TokenText()
+	p.next()
+	if p.tok != ':' {
+		p.errorfAt(pos, "unknown command %q", name)
+		p.recover()
+		return nil, false
+	}
+	p.next()
+	return LabelCmd{Pos: pos, Name: name}, true
+}
+
+// runProgram executes stmts against w, resolving labels for GOTO and
+// expanding REPEAT blocks, writing runtime diagnostics to out.
+func runProgram(stmts []Node, w *robot.World, out io.Writer) {
+	labels := make(map[string]int)
+	for i, n := range stmts {
+		if l, ok := n.(LabelCmd); ok {
+			labels[l.Name] = i
+		}
+	}
+
+	for pc := 0; pc < len(stmts); pc++ {
+		switch n := stmts[pc].(type) {
+		case GotoCmd:
+			target, ok := labels[n.Label]
+			if !ok {
+				fmt.Fprintln(out, Diagnostic{Severity: Error, Msg: fmt.Sprintf("undefined label %q", n.Label)})
+				continue
+			}
+			pc = target
+		case RepeatCmd:
+			// Run the body as its own nested program so GOTO, labels, and
+			// further REPEATs inside it resolve against the body's own
+			// labels instead of being silently skipped.
+			for i := 0; i < n.N; i++ {
+				runProgram(n.Body, w, out)
+			}
+		case LabelCmd:
+			// Marks a jump target; nothing to execute.
+		default:
+			exec(n, w)
+		}
+	}
+}
+
+func exec(n Node, w *robot.World) {
+	switch c := n.(type) {
+	case PlaceCmd:
+		w.Place(c.RobotID, c.X, c.Y, c.F)
+	case PlaceObstacleCmd:
+		w.Grid.AddObstacle(grid.Location{X: c.X, Y: c.Y})
+	case PlaceHazardCmd:
+		w.Grid.AddHazard(grid.Location{X: c.X, Y: c.Y})
+	case MoveCmd:
+		w.Move(c.RobotID)
+	case TurnCmd:
+		if c.Dir == TurnLeft {
+			w.TurnLeft(c.RobotID)
+		} else {
+			w.TurnRight(c.RobotID)
+		}
+	case ReportCmd:
+		if c.RobotID == "" {
+			if output := w.ReportAll(); output != "" {
+				fmt.Print(output)
+			}
+		} else if output := w.Report(c.RobotID); output != "" {
+			fmt.Println(output)
+		}
+	case MapCmd:
+		fmt.Print(w.Map())
+	case TickCmd:
+		w.Tick()
+	}
+}
@@ -0,0 +1,237 @@
+package cmdlang
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joshjon/verve-example/grid"
+	"github.com/joshjon/verve-example/robot"
+)
+
+func TestParseValidProgram(t *testing.T) {
+	src := `
+		# set up the tabletop
+		PLACE_OBSTACLE 2,2
+
+		PLACE 0,0,NORTH
+		MOVE
+		LEFT
+		REPORT
+	`
+	prog, diags := Parse(strings.NewReader(src))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(prog.stmts) != 5 {
+		t.Fatalf("expected 5 statements, got %d", len(prog.stmts))
+	}
+	if _, ok := prog.stmts[0].(PlaceObstacleCmd); !ok {
+		t.Errorf("stmt 0 = %T, want PlaceObstacleCmd", prog.stmts[0])
+	}
+	if _, ok := prog.stmts[1].(PlaceCmd); !ok {
+		t.Errorf("stmt 1 = %T, want PlaceCmd", prog.stmts[1])
+	}
+}
+
+func TestParseUnknownCommandReportsDiagnostic(t *testing.T) {
+	_, diags := Parse(strings.NewReader("FLY 1,2"))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Severity != Error {
+		t.Errorf("expected Error severity, got %v", diags[0].Severity)
+	}
+}
+
+func TestParseUnknownCommandReportsItsOwnLine(t *testing.T) {
+	_, diags := Parse(strings.NewReader("PLACE 0,0,NORTH\nFOO\nMOVE\n"))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Line != 2 {
+		t.Errorf("diagnostic line = %d, want 2 (FOO's line)", diags[0].Line)
+	}
+}
+
+func TestParseRecoversAfterBadStatement(t *testing.T) {
+	src := "FLY 1,2\nMOVE\n"
+	prog, diags := Parse(strings.NewReader(src))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	if len(prog.stmts) != 1 {
+		t.Fatalf("expected the MOVE after the bad line to still parse, got %d stmts", len(prog.stmts))
+	}
+	if _, ok := prog.stmts[0].(MoveCmd); !ok {
+		t.Errorf("stmt 0 = %T, want MoveCmd", prog.stmts[0])
+	}
+}
+
+func TestParseStrayClosingBraceDoesNotHang(t *testing.T) {
+	src := "PLACE 0,0,NORTH\n}\nMOVE\n"
+
+	done := make(chan struct {
+		prog  Program
+		diags []Diagnostic
+	})
+	go func() {
+		prog, diags := Parse(strings.NewReader(src))
+		done <- struct {
+			prog  Program
+			diags []Diagnostic
+		}{prog, diags}
+	}()
+
+	select {
+	case result := <-done:
+		if len(result.diags) != 1 {
+			t.Fatalf("expected 1 diagnostic, got %d: %v", len(result.diags), result.diags)
+		}
+		if len(result.prog.stmts) != 2 {
+			t.Fatalf("expected the PLACE and the MOVE after the stray '}' to still parse, got %d stmts", len(result.prog.stmts))
+		}
+		if _, ok := result.prog.stmts[1].(MoveCmd); !ok {
+			t.Errorf("stmt 1 = %T, want MoveCmd", result.prog.stmts[1])
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Parse did not return; a stray top-level '}' likely sent recover into an infinite loop")
+	}
+}
+
+func TestParseLabelsRepeatAndGoto(t *testing.T) {
+	src := `
+		PLACE 0,0,NORTH
+		loop:
+		REPEAT 2 {
+			MOVE
+		}
+		GOTO loop
+	`
+	prog, diags := Parse(strings.NewReader(src))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var kinds []string
+	for _, n := range prog.stmts {
+		switch v := n.(type) {
+		case LabelCmd:
+			kinds = append(kinds, "label:"+v.Name)
+		case RepeatCmd:
+			kinds = append(kinds, "repeat")
+			if len(v.Body) != 1 {
+				t.Errorf("repeat body = %d stmts, want 1", len(v.Body))
+			}
+		case GotoCmd:
+			kinds = append(kinds, "goto:"+v.Label)
+		default:
+			kinds = append(kinds, "other")
+		}
+	}
+	want := []string{"other", "label:loop", "repeat", "goto:loop"}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Errorf("stmt kinds = %v, want %v", kinds, want)
+	}
+}
+
+func TestRunPlaceMoveReport(t *testing.T) {
+	prog, diags := Parse(strings.NewReader("PLACE 0,0,NORTH\nMOVE\nREPORT\n"))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	w := robot.NewWorld(grid.New(5, 5, grid.Block))
+	prog.Run(w)
+
+	r := w.Robots[""]
+	if r.X != 0 || r.Y != 1 || r.F != robot.NORTH {
+		t.Errorf("robot state = (%d,%d,%s), want (0,1,NORTH)", r.X, r.Y, r.F)
+	}
+}
+
+func TestRunRepeat(t *testing.T) {
+	prog, diags := Parse(strings.NewReader("PLACE 0,0,NORTH\nREPEAT 3 {\nMOVE\n}\n"))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	w := robot.NewWorld(grid.New(5, 5, grid.Block))
+	prog.Run(w)
+
+	if w.Robots[""].Y != 3 {
+		t.Errorf("expected 3 repeated MOVEs to reach Y=3, got Y=%d", w.Robots[""].Y)
+	}
+}
+
+func TestRunObstaclesAndHazards(t *testing.T) {
+	prog, diags := Parse(strings.NewReader("PLACE_OBSTACLE 0,1\nPLACE 0,0,NORTH\nMOVE\n"))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	w := robot.NewWorld(grid.New(5, 5, grid.Block))
+	prog.Run(w)
+
+	if w.Robots[""].Y != 0 {
+		t.Errorf("expected MOVE to be blocked by the obstacle, got Y=%d", w.Robots[""].Y)
+	}
+}
+
+func TestRunGotoInsideRepeatBodySkipsStatements(t *testing.T) {
+	src := "PLACE 0,0,NORTH\nREPEAT 1 {\nGOTO skip\nMOVE\nskip:\n}\n"
+	prog, diags := Parse(strings.NewReader(src))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	w := robot.NewWorld(grid.New(5, 5, grid.Block))
+	prog.Run(w)
+
+	if r := w.Robots[""]; r.Y != 0 {
+		t.Errorf("GOTO inside REPEAT should have skipped MOVE, but Y=%d", r.Y)
+	}
+}
+
+func TestRunMultiRobotMoveAndTick(t *testing.T) {
+	src := `
+		PLACE R1 0,0,EAST
+		PLACE R2 4,0,WEST
+		TICK
+		REPORT R1
+		REPORT R2
+	`
+	prog, diags := Parse(strings.NewReader(src))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	w := robot.NewWorld(grid.New(5, 5, grid.Block))
+	prog.Run(w)
+
+	if r1 := w.Robots["R1"]; r1.X != 1 || r1.Y != 0 {
+		t.Errorf("R1 state = (%d,%d), want (1,0)", r1.X, r1.Y)
+	}
+	if r2 := w.Robots["R2"]; r2.X != 3 || r2.Y != 0 {
+		t.Errorf("R2 state = (%d,%d), want (3,0)", r2.X, r2.Y)
+	}
+}
+
+func TestRunMoveBlockedByAnotherRobot(t *testing.T) {
+	src := `
+		PLACE R1 0,0,EAST
+		PLACE R2 1,0,NORTH
+		MOVE R1
+	`
+	prog, diags := Parse(strings.NewReader(src))
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	w := robot.NewWorld(grid.New(5, 5, grid.Block))
+	prog.Run(w)
+
+	if r1 := w.Robots["R1"]; r1.X != 0 || r1.Y != 0 {
+		t.Errorf("R1 should not move onto R2's cell, got (%d,%d)", r1.X, r1.Y)
+	}
+}
@@ -0,0 +1,228 @@
+// Package grid provides the tabletop a Robot moves on: its dimensions,
+// its boundary policy, and the conversions between locations and
+// row/column coordinates.
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Boundary describes how the grid handles a move that would otherwise
+// leave its bounds.
+type Boundary int
+
+const (
+	// Block fails the move and leaves the position unchanged.
+	Block Boundary = iota
+	// Wrap makes the grid toroidal: leaving one edge reenters from the
+	// opposite edge.
+	Wrap
+	// Clamp pins the destination to the nearest in-bounds cell instead
+	// of failing the move.
+	Clamp
+)
+
+// String returns the flag-compatible name of the boundary policy.
+func (b Boundary) String() string {
+	switch b {
+	case Block:
+		return "block"
+	case Wrap:
+		return "wrap"
+	case Clamp:
+		return "clamp"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseBoundary converts a string, as accepted by the -boundary flag,
+// into a Boundary policy.
+func ParseBoundary(s string) (Boundary, error) {
+	switch s {
+	case "block":
+		return Block, nil
+	case "wrap":
+		return Wrap, nil
+	case "clamp":
+		return Clamp, nil
+	default:
+		return Block, fmt.Errorf("invalid boundary policy: %s", s)
+	}
+}
+
+// Direction mirrors the robot's cardinal directions so Grid can compute
+// moves without depending on the main package. The iota order matches
+// main.Direction, so callers can convert between the two with a plain
+// type conversion.
+type Direction int
+
+const (
+	North Direction = iota
+	East
+	South
+	West
+)
+
+// String returns the single-letter rendering of a direction, as used by
+// Render to mark the robot's facing.
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "N"
+	case East:
+		return "E"
+	case South:
+		return "S"
+	case West:
+		return "W"
+	default:
+		return "?"
+	}
+}
+
+// Location is a position on the grid.
+type Location struct {
+	X, Y int
+}
+
+// Grid owns a tabletop's width, height, and boundary policy, plus any
+// obstacle and hazard cells marked on it.
+type Grid struct {
+	Width    int
+	Height   int
+	Boundary Boundary
+
+	obstacles map[Location]bool
+	hazards   map[Location]bool
+}
+
+// New creates a Grid with the given dimensions and boundary policy.
+func New(width, height int, boundary Boundary) *Grid {
+	return &Grid{Width: width, Height: height, Boundary: boundary}
+}
+
+// AddObstacle marks loc as impassable.
+func (g *Grid) AddObstacle(loc Location) {
+	if g.obstacles == nil {
+		g.obstacles = make(map[Location]bool)
+	}
+	g.obstacles[loc] = true
+}
+
+// IsObstacle reports whether loc is marked impassable.
+func (g *Grid) IsObstacle(loc Location) bool {
+	return g.obstacles[loc]
+}
+
+// AddHazard marks loc as a failure state: stepping onto it destroys a robot.
+func (g *Grid) AddHazard(loc Location) {
+	if g.hazards == nil {
+		g.hazards = make(map[Location]bool)
+	}
+	g.hazards[loc] = true
+}
+
+// IsHazard reports whether loc is marked as a hazard.
+func (g *Grid) IsHazard(loc Location) bool {
+	return g.hazards[loc]
+}
+
+// Render draws an ASCII map of the grid: '.' for an empty cell, '*' for
+// an obstacle, '!' for a hazard, and any rune supplied in markers (e.g.
+// a robot's facing letter) overlaid on top of its keyed location. Rows
+// are printed north to south so the map reads the same way up as the
+// tabletop.
+func (g *Grid) Render(markers map[Location]rune) string {
+	var sb strings.Builder
+	for row := g.Height - 1; row >= 0; row-- {
+		for col := 0; col < g.Width; col++ {
+			loc := g.FromRowCol(row, col)
+			switch {
+			case markers[loc] != 0:
+				sb.WriteRune(markers[loc])
+			case g.IsObstacle(loc):
+				sb.WriteString("*")
+			case g.IsHazard(loc):
+				sb.WriteString("!")
+			default:
+				sb.WriteString(".")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// IsValid reports whether (x, y) lies within the grid. Under Wrap every
+// integer coordinate is valid, since it is wrapped into range before use.
+func (g *Grid) IsValid(x, y int) bool {
+	if g.Boundary == Wrap {
+		return true
+	}
+	return x >= 0 && x < g.Width && y >= 0 && y < g.Height
+}
+
+// FromRowCol builds a Location from a (row, col) pair, with row 0 at the
+// south edge and col 0 at the west edge.
+func (g *Grid) FromRowCol(row, col int) Location {
+	return Location{X: col, Y: row}
+}
+
+// FromLocation returns the (row, col) pair for a Location.
+func (g *Grid) FromLocation(loc Location) (row, col int) {
+	return loc.Y, loc.X
+}
+
+// Move computes the result of stepping loc one unit in direction d,
+// applying the grid's boundary policy. ok is false only when the
+// Block policy would take the position out of bounds, in which case
+// dest equals loc unchanged.
+func (g *Grid) Move(loc Location, d Direction) (dest Location, ok bool) {
+	dest = loc
+	switch d {
+	case North:
+		dest.Y++
+	case East:
+		dest.X++
+	case South:
+		dest.Y--
+	case West:
+		dest.X--
+	}
+
+	switch g.Boundary {
+	case Wrap:
+		dest.X = wrap(dest.X, g.Width)
+		dest.Y = wrap(dest.Y, g.Height)
+		return dest, true
+	case Clamp:
+		dest.X = clampTo(dest.X, g.Width)
+		dest.Y = clampTo(dest.Y, g.Height)
+		return dest, true
+	default: // Block
+		if dest.X < 0 || dest.X >= g.Width || dest.Y < 0 || dest.Y >= g.Height {
+			return loc, false
+		}
+		return dest, true
+	}
+}
+
+func wrap(v, n int) int {
+	v %= n
+	if v < 0 {
+		v += n
+	}
+	return v
+}
+
+func clampTo(v, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
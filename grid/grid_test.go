@@ -0,0 +1,149 @@
+package grid
+
+import "testing"
+
+func TestParseBoundary(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Boundary
+		wantErr  bool
+	}{
+		{"block", Block, false},
+		{"wrap", Wrap, false},
+		{"clamp", Clamp, false},
+		{"bogus", Block, true},
+	}
+
+	for _, test := range tests {
+		b, err := ParseBoundary(test.input)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseBoundary(%s) error = %v, wantErr %v", test.input, err, test.wantErr)
+		}
+		if !test.wantErr && b != test.expected {
+			t.Errorf("ParseBoundary(%s) = %v, expected %v", test.input, b, test.expected)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	g := New(5, 5, Block)
+	if !g.IsValid(0, 0) || !g.IsValid(4, 4) {
+		t.Error("corners of a 5x5 grid should be valid")
+	}
+	if g.IsValid(5, 0) || g.IsValid(0, -1) {
+		t.Error("out-of-range coordinates should be invalid")
+	}
+}
+
+func TestIsValidWrapAlwaysTrue(t *testing.T) {
+	g := New(5, 5, Wrap)
+	if !g.IsValid(100, -100) {
+		t.Error("Wrap grids should accept any coordinate")
+	}
+}
+
+func TestFromRowColAndBack(t *testing.T) {
+	g := New(5, 5, Block)
+	loc := g.FromRowCol(2, 3)
+	if loc.X != 3 || loc.Y != 2 {
+		t.Errorf("FromRowCol(2, 3) = %+v, want X=3 Y=2", loc)
+	}
+	row, col := g.FromLocation(loc)
+	if row != 2 || col != 3 {
+		t.Errorf("FromLocation(%+v) = (%d, %d), want (2, 3)", loc, row, col)
+	}
+}
+
+func TestMoveBlock(t *testing.T) {
+	g := New(5, 5, Block)
+	dest, ok := g.Move(Location{X: 0, Y: 4}, North)
+	if ok {
+		t.Error("Move should fail when blocked at the north edge")
+	}
+	if dest != (Location{X: 0, Y: 4}) {
+		t.Error("blocked Move should leave the location unchanged")
+	}
+
+	dest, ok = g.Move(Location{X: 0, Y: 0}, East)
+	if !ok || dest != (Location{X: 1, Y: 0}) {
+		t.Errorf("Move East from (0,0) = %+v, ok=%v, want (1,0), true", dest, ok)
+	}
+}
+
+func TestMoveWrap(t *testing.T) {
+	g := New(5, 5, Wrap)
+	dest, ok := g.Move(Location{X: 0, Y: 4}, North)
+	if !ok || dest != (Location{X: 0, Y: 0}) {
+		t.Errorf("Move North off the top edge = %+v, ok=%v, want (0,0), true", dest, ok)
+	}
+
+	dest, ok = g.Move(Location{X: 0, Y: 0}, West)
+	if !ok || dest != (Location{X: 4, Y: 0}) {
+		t.Errorf("Move West off the left edge = %+v, ok=%v, want (4,0), true", dest, ok)
+	}
+}
+
+func TestObstacles(t *testing.T) {
+	g := New(5, 5, Block)
+	loc := Location{X: 2, Y: 2}
+	if g.IsObstacle(loc) {
+		t.Error("unmarked cell should not be an obstacle")
+	}
+	g.AddObstacle(loc)
+	if !g.IsObstacle(loc) {
+		t.Error("marked cell should be an obstacle")
+	}
+}
+
+func TestHazards(t *testing.T) {
+	g := New(5, 5, Block)
+	loc := Location{X: 1, Y: 1}
+	if g.IsHazard(loc) {
+		t.Error("unmarked cell should not be a hazard")
+	}
+	g.AddHazard(loc)
+	if !g.IsHazard(loc) {
+		t.Error("marked cell should be a hazard")
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := New(3, 2, Block)
+	g.AddObstacle(Location{X: 1, Y: 0})
+	g.AddHazard(Location{X: 2, Y: 1})
+
+	got := g.Render(map[Location]rune{{X: 0, Y: 1}: 'N'})
+	want := "N.!\n.*.\n"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderNoMarkers(t *testing.T) {
+	g := New(2, 1, Block)
+	got := g.Render(nil)
+	want := "..\n"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderMultipleMarkers(t *testing.T) {
+	g := New(2, 2, Block)
+	got := g.Render(map[Location]rune{
+		{X: 0, Y: 0}: 'N',
+		{X: 1, Y: 1}: 'E',
+	})
+	want := ".E\nN.\n"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestMoveClamp(t *testing.T) {
+	g := New(5, 5, Clamp)
+	dest, ok := g.Move(Location{X: 4, Y: 4}, North)
+	if !ok || dest != (Location{X: 4, Y: 4}) {
+		t.Errorf("Move North off the top edge = %+v, ok=%v, want (4,4), true", dest, ok)
+	}
+}
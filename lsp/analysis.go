@@ -0,0 +1,86 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joshjon/verve-example/cmdlang"
+	"github.com/joshjon/verve-example/grid"
+)
+
+// analyze parses src and turns its parse diagnostics, plus two checks the
+// parser can't make on its own, into LSP Diagnostics: a PLACE whose
+// coordinates fall outside g, and a MOVE/LEFT/RIGHT issued for a robot
+// that hasn't been successfully PLACEd yet. Placed-state is tracked
+// per robot id by walking the statements in source order, descending
+// into REPEAT bodies.
+func analyze(src string, g *grid.Grid) []Diagnostic {
+	prog, diags := cmdlang.Parse(strings.NewReader(src))
+
+	out := make([]Diagnostic, 0, len(diags))
+	for _, d := range diags {
+		out = append(out, toDiagnostic(cmdlang.Pos{Line: d.Line, Col: d.Col}, d.Severity == cmdlang.Error, d.Msg))
+	}
+
+	placed := map[string]bool{}
+	var walk func(stmts []cmdlang.Node)
+	walk = func(stmts []cmdlang.Node) {
+		for _, n := range stmts {
+			switch c := n.(type) {
+			case cmdlang.PlaceCmd:
+				if !g.IsValid(c.X, c.Y) {
+					out = append(out, errorAt(c.Pos, fmt.Sprintf("PLACE %d,%d is out of range for a %dx%d grid", c.X, c.Y, g.Width, g.Height)))
+				} else {
+					placed[c.RobotID] = true
+				}
+			case cmdlang.MoveCmd:
+				requirePlaced(&out, placed, c.RobotID, c.Pos, "MOVE")
+			case cmdlang.TurnCmd:
+				word := "LEFT"
+				if c.Dir == cmdlang.TurnRight {
+					word = "RIGHT"
+				}
+				requirePlaced(&out, placed, c.RobotID, c.Pos, word)
+			case cmdlang.RepeatCmd:
+				walk(c.Body)
+			}
+		}
+	}
+	walk(prog.Statements())
+
+	return out
+}
+
+// requirePlaced appends a diagnostic at pos if robotID hasn't been
+// recorded as placed yet.
+func requirePlaced(out *[]Diagnostic, placed map[string]bool, robotID string, pos cmdlang.Pos, word string) {
+	if placed[robotID] {
+		return
+	}
+	who := "the robot"
+	if robotID != "" {
+		who = robotID
+	}
+	*out = append(*out, errorAt(pos, fmt.Sprintf("%s before %s has been PLACEd", word, who)))
+}
+
+func errorAt(pos cmdlang.Pos, msg string) Diagnostic {
+	return toDiagnostic(pos, true, msg)
+}
+
+// toDiagnostic converts a 1-based cmdlang.Pos into a zero-based LSP
+// Diagnostic spanning a single character.
+func toDiagnostic(pos cmdlang.Pos, isError bool, msg string) Diagnostic {
+	sev := SeverityError
+	if !isError {
+		sev = SeverityWarning
+	}
+	return Diagnostic{
+		Range: Range{
+			Start: Position{Line: pos.Line - 1, Character: pos.Col - 1},
+			End:   Position{Line: pos.Line - 1, Character: pos.Col},
+		},
+		Severity: sev,
+		Message:  msg,
+	}
+}
@@ -0,0 +1,45 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/joshjon/verve-example/grid"
+)
+
+func TestAnalyzeOutOfRangePlace(t *testing.T) {
+	diags := analyze("PLACE 9,9,NORTH\n", grid.New(5, 5, grid.Block))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Range.Start.Line != 0 {
+		t.Errorf("diagnostic line = %d, want 0", diags[0].Range.Start.Line)
+	}
+}
+
+func TestAnalyzeMoveBeforePlace(t *testing.T) {
+	diags := analyze("MOVE\n", grid.New(5, 5, grid.Block))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+}
+
+func TestAnalyzeValidProgramHasNoDiagnostics(t *testing.T) {
+	diags := analyze("PLACE 0,0,NORTH\nMOVE\nLEFT\nREPORT\n", grid.New(5, 5, grid.Block))
+	if len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestAnalyzeMultiRobotPlacedStateIsPerRobot(t *testing.T) {
+	diags := analyze("PLACE R1 0,0,NORTH\nMOVE R2\n", grid.New(5, 5, grid.Block))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic for R2's unplaced MOVE, got %d: %v", len(diags), diags)
+	}
+}
+
+func TestAnalyzePlaceInsideRepeatCountsAsPlaced(t *testing.T) {
+	diags := analyze("REPEAT 1 {\nPLACE 0,0,NORTH\nMOVE\n}\n", grid.New(5, 5, grid.Block))
+	if len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
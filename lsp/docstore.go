@@ -0,0 +1,43 @@
+package lsp
+
+// docStore caches open document text keyed by URI. A single goroutine
+// owns the map so didOpen/didChange/didClose notifications, which arrive
+// on the same request loop that later needs to read the text for
+// diagnostics and hover, never race each other.
+type docStore struct {
+	ops chan func(map[string]string)
+}
+
+func newDocStore() *docStore {
+	d := &docStore{ops: make(chan func(map[string]string))}
+	go d.run()
+	return d
+}
+
+func (d *docStore) run() {
+	docs := make(map[string]string)
+	for op := range d.ops {
+		op(docs)
+	}
+}
+
+// set records text as the current contents of uri.
+func (d *docStore) set(uri, text string) {
+	d.ops <- func(docs map[string]string) { docs[uri] = text }
+}
+
+// delete forgets uri, e.g. once it's closed in the editor.
+func (d *docStore) delete(uri string) {
+	d.ops <- func(docs map[string]string) { delete(docs, uri) }
+}
+
+// get returns uri's cached text, or ok=false if it isn't open.
+func (d *docStore) get(uri string) (text string, ok bool) {
+	done := make(chan struct{})
+	d.ops <- func(docs map[string]string) {
+		text, ok = docs[uri]
+		close(done)
+	}
+	<-done
+	return text, ok
+}
@@ -0,0 +1,127 @@
+package lsp
+
+// This file defines the small slice of the Language Server Protocol that
+// the robot command language server speaks. Field names and JSON tags
+// follow the LSP spec so editors can decode them without translation;
+// unused parts of the spec (workspace folders, code actions, and so on)
+// are left out rather than stubbed.
+
+// Position is a zero-based line/character offset, per the LSP spec. It is
+// one less than cmdlang.Pos in both fields.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a span between two Positions.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// DiagnosticSeverity mirrors the LSP severity enum.
+type DiagnosticSeverity int
+
+const (
+	SeverityError   DiagnosticSeverity = 1
+	SeverityWarning DiagnosticSeverity = 2
+)
+
+// Diagnostic is a single problem reported against a document.
+type Diagnostic struct {
+	Range    Range              `json:"range"`
+	Severity DiagnosticSeverity `json:"severity"`
+	Message  string             `json:"message"`
+}
+
+// TextDocumentItem describes a document as sent by didOpen.
+type TextDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+// VersionedTextDocumentIdentifier identifies a document by URI, ignoring
+// the version number the client sends.
+type VersionedTextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// TextDocumentContentChangeEvent is one entry of a didChange notification.
+// The server only supports full-document sync, so Text is the whole file.
+type TextDocumentContentChangeEvent struct {
+	Text string `json:"text"`
+}
+
+// DidOpenTextDocumentParams is the payload of textDocument/didOpen.
+type DidOpenTextDocumentParams struct {
+	TextDocument TextDocumentItem `json:"textDocument"`
+}
+
+// DidChangeTextDocumentParams is the payload of textDocument/didChange.
+type DidChangeTextDocumentParams struct {
+	TextDocument   VersionedTextDocumentIdentifier  `json:"textDocument"`
+	ContentChanges []TextDocumentContentChangeEvent `json:"contentChanges"`
+}
+
+// DidCloseTextDocumentParams is the payload of textDocument/didClose.
+type DidCloseTextDocumentParams struct {
+	TextDocument VersionedTextDocumentIdentifier `json:"textDocument"`
+}
+
+// TextDocumentIdentifier names a document by URI alone.
+type TextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// TextDocumentPositionParams locates a position within a document, the
+// shape shared by completion and hover requests.
+type TextDocumentPositionParams struct {
+	TextDocument TextDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// PublishDiagnosticsParams is the payload of the textDocument/publishDiagnostics notification.
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// CompletionItemKind mirrors the subset of the LSP enum the server uses.
+type CompletionItemKind int
+
+const (
+	KindKeyword CompletionItemKind = 14
+)
+
+// CompletionItem is one completion suggestion.
+type CompletionItem struct {
+	Label string             `json:"label"`
+	Kind  CompletionItemKind `json:"kind"`
+}
+
+// MarkupContent is plain-text hover content.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is the result of a textDocument/hover request.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+}
+
+// ServerCapabilities advertises what the server supports in its
+// initialize response.
+type ServerCapabilities struct {
+	TextDocumentSync   int               `json:"textDocumentSync"`
+	CompletionProvider CompletionOptions `json:"completionProvider"`
+	HoverProvider      bool              `json:"hoverProvider"`
+}
+
+// CompletionOptions configures completion support.
+type CompletionOptions struct{}
+
+// InitializeResult is the result of the initialize request.
+type InitializeResult struct {
+	Capabilities ServerCapabilities `json:"capabilities"`
+}
@@ -0,0 +1,30 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteThenReadMessageRoundTrips(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeMessage(&buf, []byte(`{"hello":"world"}`)); err != nil {
+		t.Fatalf("writeMessage: %v", err)
+	}
+
+	got, err := readMessage(bufio.NewReader(&buf))
+	if err != nil {
+		t.Fatalf("readMessage: %v", err)
+	}
+	if string(got) != `{"hello":"world"}` {
+		t.Errorf("readMessage() = %q, want %q", got, `{"hello":"world"}`)
+	}
+}
+
+func TestReadMessageMissingContentLength(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("\r\n{}"))
+	if _, err := readMessage(r); err == nil {
+		t.Error("expected an error for a message with no Content-Length header")
+	}
+}
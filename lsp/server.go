@@ -0,0 +1,182 @@
+// Package lsp implements a Language Server Protocol server for the robot
+// command language, speaking JSON-RPC 2.0 over stdio so editors can lint
+// .robot files live as they're written.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/joshjon/verve-example/cmdlang"
+	"github.com/joshjon/verve-example/grid"
+)
+
+// commandKeywords and directionNames are offered as completion items.
+var (
+	commandKeywords = []string{"PLACE", "MOVE", "LEFT", "RIGHT", "REPORT"}
+	directionNames  = []string{"NORTH", "EAST", "SOUTH", "WEST"}
+)
+
+// Server answers LSP requests for .robot files against a grid of a fixed
+// size, matching whatever -w/-h the editor's workspace is configured for.
+type Server struct {
+	grid *grid.Grid
+	docs *docStore
+}
+
+// NewServer creates a Server that range-checks PLACE commands against g.
+func NewServer(g *grid.Grid) *Server {
+	return &Server{grid: g, docs: newDocStore()}
+}
+
+// Run reads Content-Length-framed JSON-RPC requests from in and writes
+// responses and notifications to out until in is exhausted or an exit
+// notification is received.
+func (s *Server) Run(in io.Reader, out io.Writer) error {
+	r := bufio.NewReader(in)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+
+		result, rpcErr := s.dispatch(req, out)
+		if req.ID == nil {
+			continue // notification: no response expected
+		}
+		data, err := json.Marshal(response{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr})
+		if err != nil {
+			return err
+		}
+		if err := writeMessage(out, data); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) dispatch(req request, out io.Writer) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return InitializeResult{Capabilities: ServerCapabilities{
+			TextDocumentSync:   1, // full document sync
+			CompletionProvider: CompletionOptions{},
+			HoverProvider:      true,
+		}}, nil
+
+	case "textDocument/didOpen":
+		var p DidOpenTextDocumentParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, nil
+		}
+		s.docs.set(p.TextDocument.URI, p.TextDocument.Text)
+		s.publishDiagnostics(out, p.TextDocument.URI, p.TextDocument.Text)
+
+	case "textDocument/didChange":
+		var p DidChangeTextDocumentParams
+		if err := json.Unmarshal(req.Params, &p); err != nil || len(p.ContentChanges) == 0 {
+			return nil, nil
+		}
+		text := p.ContentChanges[len(p.ContentChanges)-1].Text
+		s.docs.set(p.TextDocument.URI, text)
+		s.publishDiagnostics(out, p.TextDocument.URI, text)
+
+	case "textDocument/didClose":
+		var p DidCloseTextDocumentParams
+		if err := json.Unmarshal(req.Params, &p); err == nil {
+			s.docs.delete(p.TextDocument.URI)
+		}
+
+	case "textDocument/completion":
+		return completionList(), nil
+
+	case "textDocument/hover":
+		var p TextDocumentPositionParams
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, nil
+		}
+		text, ok := s.docs.get(p.TextDocument.URI)
+		if !ok {
+			return nil, nil
+		}
+		return placeHover(text, p.Position), nil
+	}
+	return nil, nil
+}
+
+// publishDiagnostics analyzes text and sends the result as an unsolicited
+// textDocument/publishDiagnostics notification.
+func (s *Server) publishDiagnostics(out io.Writer, uri, text string) {
+	note := notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  PublishDiagnosticsParams{URI: uri, Diagnostics: analyze(text, s.grid)},
+	}
+	data, err := json.Marshal(note)
+	if err != nil {
+		return
+	}
+	writeMessage(out, data)
+}
+
+// completionList offers the command keywords and direction names; the
+// robot language has no other identifiers worth completing.
+func completionList() []CompletionItem {
+	items := make([]CompletionItem, 0, len(commandKeywords)+len(directionNames))
+	for _, k := range commandKeywords {
+		items = append(items, CompletionItem{Label: k, Kind: KindKeyword})
+	}
+	for _, d := range directionNames {
+		items = append(items, CompletionItem{Label: d, Kind: KindKeyword})
+	}
+	return items
+}
+
+// placeHover returns hover text for the PLACE command on pos's line, or
+// nil if that line isn't a (successfully parsed) PLACE.
+func placeHover(text string, pos Position) *Hover {
+	prog, _ := cmdlang.Parse(strings.NewReader(text))
+	line := pos.Line + 1 // LSP positions are zero-based; cmdlang.Pos is one-based
+
+	var found *cmdlang.PlaceCmd
+	var walk func(stmts []cmdlang.Node)
+	walk = func(stmts []cmdlang.Node) {
+		for _, n := range stmts {
+			switch c := n.(type) {
+			case cmdlang.PlaceCmd:
+				if c.Pos.Line == line {
+					cmd := c
+					found = &cmd
+				}
+			case cmdlang.RepeatCmd:
+				walk(c.Body)
+			}
+		}
+	}
+	walk(prog.Statements())
+	if found == nil {
+		return nil
+	}
+
+	who := "the default robot"
+	if found.RobotID != "" {
+		who = found.RobotID
+	}
+	return &Hover{Contents: MarkupContent{
+		Kind:  "plaintext",
+		Value: fmt.Sprintf("PLACE %s at x=%d, y=%d, facing=%s", who, found.X, found.Y, found.F),
+	}}
+}
@@ -0,0 +1,113 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/joshjon/verve-example/grid"
+)
+
+// sendMessage frames msg as a Content-Length message and writes it to buf.
+func sendMessage(t *testing.T, buf *bytes.Buffer, msg interface{}) {
+	t.Helper()
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n%s", len(data), data)
+}
+
+// readAllMessages decodes every framed message in buf.
+func readAllMessages(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	r := bufio.NewReader(buf)
+	var out []map[string]interface{}
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestServerDidOpenPublishesDiagnostics(t *testing.T) {
+	var in bytes.Buffer
+	sendMessage(t, &in, request{JSONRPC: "2.0", Method: "textDocument/didOpen", Params: mustJSON(t, DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: "file:///p.robot", Text: "MOVE\n"},
+	})})
+
+	var out bytes.Buffer
+	s := NewServer(grid.New(5, 5, grid.Block))
+	if err := s.Run(&in, &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	msgs := readAllMessages(t, &out)
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 notification, got %d: %v", len(msgs), msgs)
+	}
+	if msgs[0]["method"] != "textDocument/publishDiagnostics" {
+		t.Errorf("method = %v, want textDocument/publishDiagnostics", msgs[0]["method"])
+	}
+}
+
+func TestServerHoverOnPlace(t *testing.T) {
+	var in bytes.Buffer
+	sendMessage(t, &in, request{JSONRPC: "2.0", Method: "textDocument/didOpen", Params: mustJSON(t, DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: "file:///p.robot", Text: "PLACE 1,2,NORTH\n"},
+	})})
+	sendMessage(t, &in, request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "textDocument/hover", Params: mustJSON(t, TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: "file:///p.robot"},
+		Position:     Position{Line: 0, Character: 0},
+	})})
+
+	var out bytes.Buffer
+	s := NewServer(grid.New(5, 5, grid.Block))
+	if err := s.Run(&in, &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	msgs := readAllMessages(t, &out)
+	var hoverResp map[string]interface{}
+	for _, m := range msgs {
+		if _, ok := m["result"]; ok {
+			hoverResp = m
+		}
+	}
+	if hoverResp == nil {
+		t.Fatal("expected a response with a result")
+	}
+	result, ok := hoverResp["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("result = %v, want a hover object", hoverResp["result"])
+	}
+	contents := result["contents"].(map[string]interface{})
+	if contents["value"] != "PLACE the default robot at x=1, y=2, facing=NORTH" {
+		t.Errorf("hover value = %v", contents["value"])
+	}
+}
+
+func TestCompletionListHasKeywordsAndDirections(t *testing.T) {
+	items := completionList()
+	if len(items) != len(commandKeywords)+len(directionNames) {
+		t.Fatalf("got %d completion items, want %d", len(items), len(commandKeywords)+len(directionNames))
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return data
+}
@@ -1,214 +1,73 @@
 package main
 
 import (
-	"bufio"
 	"flag"
 	"fmt"
 	"os"
-	"strings"
-)
-
-// Direction represents the cardinal directions the robot can face
-type Direction int
 
-const (
-	NORTH Direction = iota
-	EAST
-	SOUTH
-	WEST
+	"github.com/joshjon/verve-example/cmdlang"
+	"github.com/joshjon/verve-example/grid"
+	"github.com/joshjon/verve-example/lsp"
+	"github.com/joshjon/verve-example/robot"
 )
 
-// Robot represents the toy robot on the grid
-type Robot struct {
-	X        int
-	Y        int
-	F        Direction
-	Placed   bool
-}
-
-// String returns the string representation of a direction
-func (d Direction) String() string {
-	switch d {
-	case NORTH:
-		return "NORTH"
-	case EAST:
-		return "EAST"
-	case SOUTH:
-		return "SOUTH"
-	case WEST:
-		return "WEST"
-	default:
-		return "UNKNOWN"
-	}
-}
-
-// ParseDirection converts a string to a Direction
-func ParseDirection(s string) (Direction, error) {
-	switch strings.ToUpper(s) {
-	case "NORTH":
-		return NORTH, nil
-	case "EAST":
-		return EAST, nil
-	case "SOUTH":
-		return SOUTH, nil
-	case "WEST":
-		return WEST, nil
-	default:
-		return NORTH, fmt.Errorf("invalid direction: %s", s)
-	}
-}
-
-// IsValidPosition checks if a position is within the 5x5 grid
-func IsValidPosition(x, y int) bool {
-	return x >= 0 && x <= 4 && y >= 0 && y <= 4
-}
-
-// Place sets the robot's position and direction
-func (r *Robot) Place(x, y int, f Direction) bool {
-	if !IsValidPosition(x, y) {
-		return false
-	}
-	r.X = x
-	r.Y = y
-	r.F = f
-	r.Placed = true
-	return true
-}
-
-// Move advances the robot one unit in its facing direction
-func (r *Robot) Move() bool {
-	if !r.Placed {
-		return false
-	}
-
-	var newX, newY int
-
-	switch r.F {
-	case NORTH:
-		newX, newY = r.X, r.Y+1
-	case EAST:
-		newX, newY = r.X+1, r.Y
-	case SOUTH:
-		newX, newY = r.X, r.Y-1
-	case WEST:
-		newX, newY = r.X-1, r.Y
-	}
-
-	if !IsValidPosition(newX, newY) {
-		return false
-	}
-
-	r.X = newX
-	r.Y = newY
-	return true
-}
-
-// TurnLeft rotates the robot 90 degrees counter-clockwise
-func (r *Robot) TurnLeft() bool {
-	if !r.Placed {
-		return false
-	}
-	r.F = (r.F + 3) % 4 // Equivalent to -1 mod 4
-	return true
-}
-
-// TurnRight rotates the robot 90 degrees clockwise
-func (r *Robot) TurnRight() bool {
-	if !r.Placed {
-		return false
-	}
-	r.F = (r.F + 1) % 4
-	return true
-}
-
-// Report returns the robot's current position and direction
-func (r *Robot) Report() string {
-	if !r.Placed {
-		return ""
-	}
-	return fmt.Sprintf("%d,%d,%s", r.X, r.Y, r.F)
-}
-
-// ProcessCommand processes a single command
-func (r *Robot) ProcessCommand(cmd string) {
-	parts := strings.Fields(strings.TrimSpace(cmd))
-	if len(parts) == 0 {
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		runLSP(os.Args[2:])
 		return
 	}
 
-	command := strings.ToUpper(parts[0])
-
-	switch command {
-	case "PLACE":
-		if len(parts) != 2 {
-			return
-		}
-		coords := strings.Split(parts[1], ",")
-		if len(coords) != 3 {
-			return
-		}
-
-		var x, y int
-		var f Direction
-		var err error
-
-		_, err = fmt.Sscanf(coords[0], "%d", &x)
-		if err != nil {
-			return
-		}
-		_, err = fmt.Sscanf(coords[1], "%d", &y)
-		if err != nil {
-			return
-		}
-		f, err = ParseDirection(coords[2])
-		if err != nil {
-			return
-		}
-
-		r.Place(x, y, f)
-
-	case "MOVE":
-		r.Move()
-
-	case "LEFT":
-		r.TurnLeft()
-
-	case "RIGHT":
-		r.TurnRight()
-
-	case "REPORT":
-		if output := r.Report(); output != "" {
-			fmt.Println(output)
-		}
-	}
-}
-
-func main() {
 	inputFile := flag.String("f", "", "input file (reads from stdin if not provided)")
+	width := flag.Int("w", 5, "grid width")
+	height := flag.Int("h", 5, "grid height")
+	boundary := flag.String("boundary", "block", "boundary policy: block, wrap, or clamp")
 	flag.Parse()
 
-	robot := &Robot{}
+	b, err := grid.ParseBoundary(*boundary)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
 
-	var scanner *bufio.Scanner
+	var input *os.File
 	if *inputFile != "" {
-		file, err := os.Open(*inputFile)
+		input, err = os.Open(*inputFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "error opening file: %v\n", err)
 			os.Exit(1)
 		}
-		defer file.Close()
-		scanner = bufio.NewScanner(file)
+		defer input.Close()
 	} else {
-		scanner = bufio.NewScanner(os.Stdin)
+		input = os.Stdin
+	}
+
+	prog, diags := cmdlang.Parse(input)
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d)
 	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		robot.ProcessCommand(line)
+	w := robot.NewWorld(grid.New(*width, *height, b))
+	prog.Run(w)
+}
+
+// runLSP starts the robot command language server, speaking LSP over
+// stdin/stdout until the client disconnects.
+func runLSP(args []string) {
+	fs := flag.NewFlagSet("lsp", flag.ExitOnError)
+	width := fs.Int("w", 5, "grid width")
+	height := fs.Int("h", 5, "grid height")
+	boundary := fs.String("boundary", "block", "boundary policy: block, wrap, or clamp")
+	fs.Parse(args)
+
+	b, err := grid.ParseBoundary(*boundary)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "error reading input: %v\n", err)
+	srv := lsp.NewServer(grid.New(*width, *height, b))
+	if err := srv.Run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "lsp error: %v\n", err)
 		os.Exit(1)
 	}
 }
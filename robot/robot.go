@@ -0,0 +1,170 @@
+// Package robot implements the toy robot itself: its position, facing,
+// and the moves it can make on a grid.Grid.
+package robot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joshjon/verve-example/grid"
+)
+
+// Direction represents the cardinal directions the robot can face
+type Direction int
+
+const (
+	NORTH Direction = iota
+	EAST
+	SOUTH
+	WEST
+)
+
+// String returns the string representation of a direction
+func (d Direction) String() string {
+	switch d {
+	case NORTH:
+		return "NORTH"
+	case EAST:
+		return "EAST"
+	case SOUTH:
+		return "SOUTH"
+	case WEST:
+		return "WEST"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParseDirection converts a string to a Direction
+func ParseDirection(s string) (Direction, error) {
+	switch strings.ToUpper(s) {
+	case "NORTH":
+		return NORTH, nil
+	case "EAST":
+		return EAST, nil
+	case "SOUTH":
+		return SOUTH, nil
+	case "WEST":
+		return WEST, nil
+	default:
+		return NORTH, fmt.Errorf("invalid direction: %s", s)
+	}
+}
+
+// Robot represents the toy robot on the grid
+type Robot struct {
+	X         int
+	Y         int
+	F         Direction
+	Placed    bool
+	Destroyed bool
+	Grid      *grid.Grid
+
+	// Occupied, when set, reports whether (x, y) is currently held by
+	// another robot. Move refuses to step onto an occupied cell. World
+	// sets this for every robot it manages; a standalone Robot leaves it
+	// nil and has no notion of other robots.
+	Occupied func(x, y int) bool
+}
+
+// grid returns the robot's configured grid, lazily creating the
+// original 5x5 blocked tabletop for a zero-value Robot. The grid is
+// cached on the robot so obstacles and hazards added to it aren't lost
+// and, crucially, aren't shared with other robots.
+func (r *Robot) grid() *grid.Grid {
+	if r.Grid == nil {
+		r.Grid = grid.New(5, 5, grid.Block)
+	}
+	return r.Grid
+}
+
+// Place sets the robot's position and direction. Re-placing a destroyed
+// robot recovers it.
+func (r *Robot) Place(x, y int, f Direction) bool {
+	if !r.grid().IsValid(x, y) {
+		return false
+	}
+	r.X = x
+	r.Y = y
+	r.F = f
+	r.Placed = true
+	r.Destroyed = false
+	return true
+}
+
+// Move advances the robot one unit in its facing direction. It refuses
+// to enter an obstacle cell or a cell held by another robot, leaving the
+// robot's state unchanged, and marks the robot destroyed if it steps
+// onto a hazard cell.
+func (r *Robot) Move() bool {
+	if !r.Placed || r.Destroyed {
+		return false
+	}
+
+	dest, ok := r.grid().Move(grid.Location{X: r.X, Y: r.Y}, grid.Direction(r.F))
+	if !ok || r.grid().IsObstacle(dest) {
+		return false
+	}
+	if r.Occupied != nil && r.Occupied(dest.X, dest.Y) {
+		return false
+	}
+
+	r.X, r.Y = dest.X, dest.Y
+	if r.grid().IsHazard(dest) {
+		r.Destroyed = true
+	}
+	return true
+}
+
+// TurnLeft rotates the robot 90 degrees counter-clockwise
+func (r *Robot) TurnLeft() bool {
+	if !r.Placed || r.Destroyed {
+		return false
+	}
+	r.F = (r.F + 3) % 4 // Equivalent to -1 mod 4
+	return true
+}
+
+// TurnRight rotates the robot 90 degrees clockwise
+func (r *Robot) TurnRight() bool {
+	if !r.Placed || r.Destroyed {
+		return false
+	}
+	r.F = (r.F + 1) % 4
+	return true
+}
+
+// Report returns the robot's current position and direction
+func (r *Robot) Report() string {
+	if !r.Placed || r.Destroyed {
+		return ""
+	}
+	return fmt.Sprintf("%d,%d,%s", r.X, r.Y, r.F)
+}
+
+// AddObstacle marks (x, y) on the robot's grid as impassable.
+func (r *Robot) AddObstacle(x, y int) {
+	r.grid().AddObstacle(grid.Location{X: x, Y: y})
+}
+
+// AddHazard marks (x, y) on the robot's grid as a hazard.
+func (r *Robot) AddHazard(x, y int) {
+	r.grid().AddHazard(grid.Location{X: x, Y: y})
+}
+
+// Map renders an ASCII map of the robot's grid: '.' for an empty cell,
+// '*' for an obstacle, '!' for a hazard, and the robot's facing letter
+// over its current cell if placed.
+func (r *Robot) Map() string {
+	markers := map[grid.Location]rune{}
+	if r.Placed && !r.Destroyed {
+		markers[grid.Location{X: r.X, Y: r.Y}] = facingRune(r.F)
+	}
+	return r.grid().Render(markers)
+}
+
+// facingRune returns the single-letter rendering of a direction, as
+// used to mark a robot's facing on a Map.
+func facingRune(f Direction) rune {
+	return rune(grid.Direction(f).String()[0])
+}
@@ -1,7 +1,9 @@
-package main
+package robot
 
 import (
 	"testing"
+
+	"github.com/joshjon/verve-example/grid"
 )
 
 func TestPlaceValid(t *testing.T) {
@@ -151,6 +153,57 @@ func TestParseDirection(t *testing.T) {
 	}
 }
 
+func TestMoveBlockedByObstacle(t *testing.T) {
+	r := &Robot{Grid: grid.New(5, 5, grid.Block)}
+	r.AddObstacle(0, 1)
+	r.Place(0, 0, NORTH)
+
+	if r.Move() {
+		t.Error("Move should fail when the destination is an obstacle")
+	}
+	if r.X != 0 || r.Y != 0 {
+		t.Error("robot should not move onto an obstacle")
+	}
+}
+
+func TestMoveOntoHazardDestroysRobot(t *testing.T) {
+	r := &Robot{Grid: grid.New(5, 5, grid.Block)}
+	r.AddHazard(0, 1)
+	r.Place(0, 0, NORTH)
+
+	if !r.Move() {
+		t.Error("Move onto a hazard should still succeed")
+	}
+	if !r.Destroyed {
+		t.Error("robot should be destroyed after stepping on a hazard")
+	}
+
+	if r.Move() || r.TurnLeft() || r.TurnRight() {
+		t.Error("a destroyed robot should ignore MOVE/LEFT/RIGHT")
+	}
+	if r.Report() != "" {
+		t.Error("a destroyed robot should report nothing")
+	}
+}
+
+func TestRePlaceRecoversDestroyedRobot(t *testing.T) {
+	r := &Robot{Grid: grid.New(5, 5, grid.Block)}
+	r.AddHazard(0, 1)
+	r.Place(0, 0, NORTH)
+	r.Move()
+	if !r.Destroyed {
+		t.Fatal("setup: robot should be destroyed")
+	}
+
+	r.Place(1, 1, EAST)
+	if r.Destroyed {
+		t.Error("re-placing should clear Destroyed")
+	}
+	if r.Report() != "1,1,EAST" {
+		t.Errorf("expected '1,1,EAST', got %q", r.Report())
+	}
+}
+
 func TestComplexScenario(t *testing.T) {
 	r := &Robot{}
 
@@ -173,7 +226,7 @@ func TestComplexScenario(t *testing.T) {
 	}
 	// MOVE
 	r.Move()
-	if r.X != -1 {
+	if r.X != 0 || r.Y != 3 {
 		t.Errorf("MOVE should fail at boundary, but position is (%d,%d)", r.X, r.Y)
 	}
 	// RIGHT
@@ -0,0 +1,218 @@
+package robot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/joshjon/verve-example/grid"
+)
+
+// World manages multiple named robots sharing a single Grid, refusing
+// to let one robot move onto a cell another placed robot occupies.
+type World struct {
+	Grid   *grid.Grid
+	Robots map[string]*Robot
+}
+
+// NewWorld creates an empty World backed by g.
+func NewWorld(g *grid.Grid) *World {
+	return &World{Grid: g, Robots: make(map[string]*Robot)}
+}
+
+// Place creates robot id the first time it's seen, then places it at
+// (x, y) facing f. Like Robot.Place, it fails if the position is
+// invalid on the world's grid, and it recovers a previously destroyed
+// robot. It also fails, leaving the robot's state unchanged, if (x, y)
+// is already held by another placed robot.
+func (w *World) Place(id string, x, y int, f Direction) bool {
+	if w.occupiedByOther(id, x, y) {
+		return false
+	}
+
+	r, ok := w.Robots[id]
+	if !ok {
+		r = &Robot{Grid: w.Grid}
+		r.Occupied = func(x, y int) bool { return w.occupiedByOther(id, x, y) }
+		w.Robots[id] = r
+	}
+	return r.Place(x, y, f)
+}
+
+// occupiedByOther reports whether (x, y) holds a placed, undestroyed
+// robot other than id. A linear scan is plenty for the handful of
+// robots a tabletop simulation expects.
+func (w *World) occupiedByOther(id string, x, y int) bool {
+	for otherID, other := range w.Robots {
+		if otherID == id {
+			continue
+		}
+		if other.Placed && !other.Destroyed && other.X == x && other.Y == y {
+			return true
+		}
+	}
+	return false
+}
+
+// Move advances robot id one step. It reports false if id hasn't been placed.
+func (w *World) Move(id string) bool {
+	r, ok := w.Robots[id]
+	if !ok {
+		return false
+	}
+	return r.Move()
+}
+
+// TurnLeft rotates robot id 90 degrees counter-clockwise.
+func (w *World) TurnLeft(id string) bool {
+	r, ok := w.Robots[id]
+	if !ok {
+		return false
+	}
+	return r.TurnLeft()
+}
+
+// TurnRight rotates robot id 90 degrees clockwise.
+func (w *World) TurnRight(id string) bool {
+	r, ok := w.Robots[id]
+	if !ok {
+		return false
+	}
+	return r.TurnRight()
+}
+
+// Report returns robot id's position and facing, or "" if it hasn't
+// been placed.
+func (w *World) Report(id string) string {
+	r, ok := w.Robots[id]
+	if !ok {
+		return ""
+	}
+	return r.Report()
+}
+
+// ReportAll returns one line per placed, undestroyed robot, sorted by
+// id. The default robot (id "") is printed bare, exactly as a
+// single-robot REPORT always has; every other robot's line is prefixed
+// with its id.
+func (w *World) ReportAll() string {
+	ids := make([]string, 0, len(w.Robots))
+	for id, r := range w.Robots {
+		if r.Placed && !r.Destroyed {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var sb strings.Builder
+	for _, id := range ids {
+		if id == "" {
+			fmt.Fprintln(&sb, w.Robots[id].Report())
+		} else {
+			fmt.Fprintf(&sb, "%s %s\n", id, w.Robots[id].Report())
+		}
+	}
+	return sb.String()
+}
+
+// Map renders an ASCII map of the world's grid with every placed,
+// undestroyed robot drawn as its facing letter.
+func (w *World) Map() string {
+	markers := make(map[grid.Location]rune, len(w.Robots))
+	for _, r := range w.Robots {
+		if r.Placed && !r.Destroyed {
+			markers[grid.Location{X: r.X, Y: r.Y}] = facingRune(r.F)
+		}
+	}
+	return w.Grid.Render(markers)
+}
+
+// Tick advances every placed, undestroyed robot one step in its facing
+// direction simultaneously. Two robots swapping places head-on cancel
+// both moves; when several robots would land on the same cell, the
+// lexicographically smallest id keeps its move and the rest stay put.
+func (w *World) Tick() {
+	type move struct {
+		from, to grid.Location
+		ok       bool
+	}
+
+	ids := make([]string, 0, len(w.Robots))
+	for id, r := range w.Robots {
+		if r.Placed && !r.Destroyed {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	moves := make(map[string]move, len(ids))
+	for _, id := range ids {
+		r := w.Robots[id]
+		from := grid.Location{X: r.X, Y: r.Y}
+		to, ok := w.Grid.Move(from, grid.Direction(r.F))
+		if ok && w.Grid.IsObstacle(to) {
+			ok = false
+		}
+		moves[id] = move{from: from, to: to, ok: ok}
+	}
+
+	// Head-on swaps cancel both moves.
+	for i, a := range ids {
+		ma := moves[a]
+		if !ma.ok {
+			continue
+		}
+		for _, b := range ids[i+1:] {
+			mb := moves[b]
+			if mb.ok && ma.to == mb.from && mb.to == ma.from {
+				ma.ok, mb.ok = false, false
+				moves[a], moves[b] = ma, mb
+				break
+			}
+		}
+	}
+
+	// Same-cell contention: the lexicographically smallest id keeps its
+	// move. A robot whose move was already cancelled (or was never going
+	// to move at all, e.g. one blocked by a boundary or obstacle) still
+	// occupies its starting cell and blocks anyone trying to move there.
+	// A cancellation can itself free up a cell for a robot we already
+	// checked, so this runs to a fixpoint rather than a single pass.
+	for changed := true; changed; {
+		changed = false
+		for i, id := range ids {
+			m := moves[id]
+			if !m.ok {
+				continue
+			}
+			for j, other := range ids {
+				if j == i {
+					continue
+				}
+				om := moves[other]
+				target := om.from
+				if om.ok {
+					target = om.to
+				}
+				if target == m.to && (j < i || !om.ok) {
+					m.ok = false
+					moves[id] = m
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	for _, id := range ids {
+		m := moves[id]
+		if !m.ok {
+			continue
+		}
+		r := w.Robots[id]
+		r.X, r.Y = m.to.X, m.to.Y
+		if w.Grid.IsHazard(m.to) {
+			r.Destroyed = true
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package robot
+
+import (
+	"testing"
+
+	"github.com/joshjon/verve-example/grid"
+)
+
+func TestWorldPlaceAndMove(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	if !w.Place("R1", 0, 0, NORTH) {
+		t.Fatal("Place should succeed for a valid position")
+	}
+	if !w.Move("R1") {
+		t.Error("Move should succeed")
+	}
+	if r := w.Robots["R1"]; r.X != 0 || r.Y != 1 {
+		t.Errorf("R1 = (%d,%d), want (0,1)", r.X, r.Y)
+	}
+}
+
+func TestWorldMoveRefusesOccupiedCell(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	w.Place("R1", 0, 0, NORTH)
+	w.Place("R2", 0, 1, EAST)
+
+	if w.Move("R1") {
+		t.Error("Move onto a cell held by another robot should fail")
+	}
+	if r := w.Robots["R1"]; r.X != 0 || r.Y != 0 {
+		t.Error("R1 should not have moved")
+	}
+}
+
+func TestWorldPlaceRefusesOccupiedCell(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	if !w.Place("R1", 2, 2, NORTH) {
+		t.Fatal("R1's Place should succeed")
+	}
+	if w.Place("R2", 2, 2, EAST) {
+		t.Error("R2 should not be able to PLACE onto R1's cell")
+	}
+	if r, ok := w.Robots["R2"]; ok && r.Placed {
+		t.Error("R2 should not be marked placed after a refused Place")
+	}
+}
+
+func TestWorldPlaceAllowsReplacingSelfOnOwnCell(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	w.Place("R1", 2, 2, NORTH)
+	if !w.Place("R1", 2, 2, EAST) {
+		t.Error("R1 should be able to re-place itself on its own cell")
+	}
+}
+
+func TestWorldReportAll(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	w.Place("", 0, 0, NORTH)
+	w.Place("R2", 1, 1, EAST)
+
+	got := w.ReportAll()
+	want := "0,0,NORTH\nR2 1,1,EAST\n"
+	if got != want {
+		t.Errorf("ReportAll() = %q, want %q", got, want)
+	}
+}
+
+func TestWorldTickHeadOnSwapCancels(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	w.Place("R1", 0, 0, EAST)
+	w.Place("R2", 1, 0, WEST)
+
+	w.Tick()
+
+	if r := w.Robots["R1"]; r.X != 0 || r.Y != 0 {
+		t.Errorf("R1 should stay put after a head-on swap, got (%d,%d)", r.X, r.Y)
+	}
+	if r := w.Robots["R2"]; r.X != 1 || r.Y != 0 {
+		t.Errorf("R2 should stay put after a head-on swap, got (%d,%d)", r.X, r.Y)
+	}
+}
+
+func TestWorldTickSameCellContentionFavorsLowerID(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	w.Place("R1", 0, 0, EAST)
+	w.Place("R2", 2, 0, WEST)
+
+	w.Tick()
+
+	if r := w.Robots["R1"]; r.X != 1 || r.Y != 0 {
+		t.Errorf("R1 should win the contested cell, got (%d,%d)", r.X, r.Y)
+	}
+	if r := w.Robots["R2"]; r.X != 2 || r.Y != 0 {
+		t.Errorf("R2 should stay put after losing the contested cell, got (%d,%d)", r.X, r.Y)
+	}
+}
+
+func TestWorldTickRefusesCellHeldByStationaryRobot(t *testing.T) {
+	w := NewWorld(grid.New(5, 5, grid.Block))
+	w.Place("Z", 4, 0, EAST) // stuck at the grid's edge, never moves
+	w.Place("A", 3, 0, EAST) // about to step onto Z's cell
+
+	w.Tick()
+
+	if r := w.Robots["A"]; r.X != 3 || r.Y != 0 {
+		t.Errorf("A should not move onto Z's cell just because \"Z\" sorts after \"A\", got (%d,%d)", r.X, r.Y)
+	}
+	if r := w.Robots["Z"]; r.X != 4 || r.Y != 0 {
+		t.Errorf("Z should stay put, got (%d,%d)", r.X, r.Y)
+	}
+}
+
+func TestWorldTickOntoHazardDestroysRobot(t *testing.T) {
+	g := grid.New(5, 5, grid.Block)
+	g.AddHazard(grid.Location{X: 0, Y: 1})
+
+	w := NewWorld(g)
+	w.Place("R1", 0, 0, NORTH)
+
+	w.Tick()
+
+	if r := w.Robots["R1"]; !r.Destroyed {
+		t.Error("R1 should be destroyed after ticking onto a hazard")
+	}
+}